@@ -90,10 +90,11 @@ func (c *Client) SetReusePort(reusePort bool) {
 }
 
 func (c *Client) SetTarget(target string) error {
-	host, port, err := getHostPort(target)
+	transport, host, port, err := getTransportHostPort(target)
 	if err != nil {
 		return err
 	}
+	c.snmp.Transport = transport
 	c.snmp.Target = host
 	c.snmp.Port = port
 	return nil
@@ -107,7 +108,14 @@ func (c *Client) SetRetries(r int) {
 	c.snmp.Retries = r
 }
 
-func (c *Client) SetSecurity(username, authPassword, privPassword string) error {
+// Context carries the optional SNMPv3 context name and context engine ID used to
+// reach a context such as a VRF or proxied sub-agent
+type Context struct {
+	Name     string
+	EngineID string
+}
+
+func (c *Client) SetSecurity(username, authPassword, privPassword string, context ...Context) error {
 	authProtocol, authPassphrase, err := getAuth(authPassword)
 	if err != nil {
 		return err
@@ -123,9 +131,18 @@ func (c *Client) SetSecurity(username, authPassword, privPassword string) error
 		PrivacyProtocol:          privProtocol,
 		PrivacyPassphrase:        privPassphrase,
 	}
+	if len(context) > 0 {
+		c.SetContext(context[0].Name, context[0].EngineID)
+	}
 	return nil
 }
 
+// SetContext sets the SNMPv3 context name and context engine ID used for subsequent requests
+func (c *Client) SetContext(name, engineID string) {
+	c.snmp.ContextName = name
+	c.snmp.ContextEngineID = engineID
+}
+
 func (c *Client) Debug(debug bool) {
 	if debug {
 		c.snmp.Logger = log.New(os.Stderr, "", 0)
@@ -134,30 +151,65 @@ func (c *Client) Debug(debug bool) {
 	}
 }
 
-func getHostPort(target string) (host string, port uint16, err error) {
-	host, portStr, err := net.SplitHostPort(target)
+// defaultTransport is used when target has no scheme
+const defaultTransport = "udp"
+
+// defaultPort is used when target has no port
+const defaultPort uint16 = 161
+
+// transports lists the schemes accepted in a URI-style target, e.g. "tcp://host:port"
+var transports = map[string]string{
+	"":     defaultTransport,
+	"udp":  "udp",
+	"udp4": "udp4",
+	"udp6": "udp6",
+	"tcp":  "tcp",
+	"tcp4": "tcp4",
+	"tcp6": "tcp6",
+}
+
+// getTransportHostPort parses a target of the form "[scheme://]host[:port]", where
+// scheme is one of the keys of transports and host may be an IPv6 literal with a
+// zone, e.g. "udp6://[fe80::1%eth0]:161". scheme and port default to "udp" and 161
+// when omitted, to preserve backward compatibility with bare "host:port" targets.
+func getTransportHostPort(target string) (transport, host string, port uint16, err error) {
+	scheme := ""
+	rest := target
+	if i := strings.Index(target, "://"); i >= 0 {
+		scheme = strings.ToLower(target[:i])
+		rest = target[i+3:]
+	}
+
+	transport, ok := transports[scheme]
+	if !ok {
+		return "", "", 0, errors.Errorf("Unknown transport scheme: %q", scheme)
+	}
+
+	host, portStr, err := net.SplitHostPort(rest)
 	if err != nil {
 		if !strings.HasSuffix(err.Error(), "missing port in address") {
-			return
+			return "", "", 0, err
 		}
-		return target, uint16(161), nil
+		return transport, strings.Trim(rest, "[]"), defaultPort, nil
 	}
+
 	var portNum int
-	portNum, err = net.LookupPort("udp", portStr)
-	return host, uint16(portNum), err
+	portNum, err = net.LookupPort(strings.TrimRight(transport, "46"), portStr)
+	return transport, host, uint16(portNum), err
 }
 
 func newSNMP(target string) (*gosnmp.GoSNMP, error) {
-	host, port, err := getHostPort(target)
+	transport, host, port, err := getTransportHostPort(target)
 	if err != nil {
 		return nil, err
 	}
 	return &gosnmp.GoSNMP{
-		Target:  host,
-		Port:    port,
-		Timeout: 10 * time.Second,
-		Retries: 3,
-		MaxOids: gosnmp.MaxOids,
+		Target:    host,
+		Port:      port,
+		Transport: transport,
+		Timeout:   10 * time.Second,
+		Retries:   3,
+		MaxOids:   gosnmp.MaxOids,
 	}, nil
 }
 
@@ -237,8 +289,10 @@ func getPriv(password string) (protocol gosnmp.SnmpV3PrivProtocol, passphrase st
 	return
 }
 
-// NewV3 creates a mew SNMPv3 Client
-func NewV3(target, username, authPassword, privPassword string) (*Client, error) {
+// NewV3 creates a mew SNMPv3 Client. An optional Context selects a non-default
+// context name and/or context engine ID, as required by some v3 agents (e.g.
+// Cisco VRFs, proxied sub-agents)
+func NewV3(target, username, authPassword, privPassword string, context ...Context) (*Client, error) {
 	snmp, err := newSNMP(target)
 	if err != nil {
 		return nil, err
@@ -275,5 +329,9 @@ func NewV3(target, username, authPassword, privPassword string) (*Client, error)
 		PrivacyProtocol:          privProtocol,
 		PrivacyPassphrase:        privPassphrase,
 	}
+	if len(context) > 0 {
+		snmp.ContextName = context[0].Name
+		snmp.ContextEngineID = context[0].EngineID
+	}
 	return &Client{snmp: snmp}, nil
 }
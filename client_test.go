@@ -0,0 +1,40 @@
+package snmpquery
+
+import "testing"
+
+func TestGetTransportHostPort(t *testing.T) {
+	tests := []struct {
+		target          string
+		transport, host string
+		port            uint16
+		wantErr         bool
+	}{
+		{target: "localhost", transport: "udp", host: "localhost", port: 161},
+		{target: "localhost:1161", transport: "udp", host: "localhost", port: 1161},
+		{target: "udp://localhost:1161", transport: "udp", host: "localhost", port: 1161},
+		{target: "udp4://localhost:1161", transport: "udp4", host: "localhost", port: 1161},
+		{target: "tcp://localhost:1161", transport: "tcp", host: "localhost", port: 1161},
+		{target: "tcp://localhost", transport: "tcp", host: "localhost", port: 161},
+		{target: "udp6://[fe80::1%eth0]:1161", transport: "udp6", host: "fe80::1%eth0", port: 1161},
+		{target: "udp6://[fe80::1%eth0]", transport: "udp6", host: "fe80::1%eth0", port: 161},
+		{target: "sctp://localhost:1161", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		transport, host, port, err := getTransportHostPort(tt.target)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("getTransportHostPort(%q): expected error, got none", tt.target)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("getTransportHostPort(%q): unexpected error: %v", tt.target, err)
+			continue
+		}
+		if transport != tt.transport || host != tt.host || port != tt.port {
+			t.Errorf("getTransportHostPort(%q) = (%q, %q, %d), want (%q, %q, %d)",
+				tt.target, transport, host, port, tt.transport, tt.host, tt.port)
+		}
+	}
+}
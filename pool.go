@@ -0,0 +1,176 @@
+package snmpquery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/sleepinggenius2/gosmi/models"
+)
+
+// Target describes a single device to poll with a Pool: a pre-configured Client
+// (e.g. from NewV1, NewV2 or NewV3) plus optional per-target overrides of the
+// retry and timeout behavior used while polling it. The Pool never mutates
+// Client itself; a Timeout override is applied to a private copy made for each
+// poll of the target and shared by all of that poll's attempts.
+type Target struct {
+	Name    string
+	Client  *Client
+	Retries int
+	Timeout time.Duration
+	Backoff time.Duration
+}
+
+// Result is the outcome of polling a single Target
+type Result struct {
+	Scalars map[string]models.Value
+	Rows    map[string]Row
+	Err     error
+}
+
+// Pool concurrently polls a set of Targets sharing a Query or Table definition,
+// bounding the number of in-flight requests to Workers
+type Pool struct {
+	Workers int
+}
+
+// NewPool creates a Pool that runs at most workers queries concurrently
+func NewPool(workers int) *Pool {
+	if workers <= 0 {
+		workers = 1
+	}
+	return &Pool{Workers: workers}
+}
+
+// GetAll runs q against every target, returning a Result keyed by Target.Name
+func (p *Pool) GetAll(targets []Target, q Query) map[string]Result {
+	return p.run(targets, func(c Client) (map[string]models.Value, map[string]Row, error) {
+		scalars, err := c.GetAll(q)
+		return scalars, nil, err
+	})
+}
+
+// Table runs table against every target, returning a Result keyed by Target.Name.
+// If inherit is given, it is fetched once per target and any value whose name
+// appears in table.InheritTags is merged into every row of that target's result.
+func (p *Pool) Table(targets []Target, table Table, inherit *Query, index ...interface{}) map[string]Result {
+	return p.run(targets, func(c Client) (map[string]models.Value, map[string]Row, error) {
+		var scalars map[string]models.Value
+		if inherit != nil {
+			var err error
+			scalars, err = c.GetAll(*inherit)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		rows, err := c.Table(table, index...)
+		if err != nil {
+			return scalars, nil, err
+		}
+
+		mergeInheritTags(table, scalars, rows)
+
+		return scalars, rows, nil
+	})
+}
+
+// mergeInheritTags copies every table.InheritTags value present in scalars into
+// the Tags of each row in rows
+func mergeInheritTags(table Table, scalars map[string]models.Value, rows map[string]Row) {
+	for _, name := range table.InheritTags {
+		val, ok := scalars[name]
+		if !ok {
+			continue
+		}
+		for key, row := range rows {
+			if row.Tags == nil {
+				row.Tags = make(map[string]models.Value, 1)
+			}
+			row.Tags[name] = val
+			rows[key] = row
+		}
+	}
+}
+
+func (p *Pool) run(targets []Target, fn func(c Client) (map[string]models.Value, map[string]Row, error)) map[string]Result {
+	results := make(map[string]Result, len(targets))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, p.Workers)
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target Target) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result := p.runTarget(target, fn)
+
+			mu.Lock()
+			results[target.Name] = result
+			mu.Unlock()
+		}(target)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func (p *Pool) runTarget(target Target, fn func(c Client) (map[string]models.Value, map[string]Row, error)) Result {
+	if target.Client == nil {
+		return Result{Err: errors.Errorf("Target %s has no client", target.Name)}
+	}
+
+	// Copy the underlying gosnmp.GoSNMP too, not just the Client wrapping it, so
+	// that overriding Timeout below doesn't mutate the *Client the caller passed in
+	snmp := *target.Client.snmp
+	c := Client{snmp: &snmp}
+	if target.Timeout > 0 {
+		c.SetTimeout(target.Timeout)
+	}
+
+	attempts := target.Retries + 1
+	backoff := target.Backoff
+	if backoff <= 0 {
+		backoff = 500 * time.Millisecond
+	}
+
+	var scalars map[string]models.Value
+	var rows map[string]Row
+	err := retry(attempts, backoff, func() error {
+		if err := c.Connect(); err != nil {
+			return err
+		}
+		defer c.Close()
+
+		var err error
+		scalars, rows, err = fn(c)
+		return err
+	})
+	if err != nil {
+		return Result{Err: errors.Wrapf(err, "Target %s", target.Name)}
+	}
+
+	return Result{Scalars: scalars, Rows: rows}
+}
+
+// retry calls fn up to attempts times, sleeping backoff (doubling each time)
+// between attempts, stopping as soon as fn succeeds. It returns fn's last error
+// if every attempt failed.
+func retry(attempts int, backoff time.Duration, fn func() error) error {
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if lastErr = fn(); lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
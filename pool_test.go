@@ -0,0 +1,71 @@
+package snmpquery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/sleepinggenius2/gosmi/models"
+)
+
+func TestRetry(t *testing.T) {
+	failures := 2
+	calls := 0
+	err := retry(3, time.Millisecond, func() error {
+		calls++
+		if calls <= failures {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("retry: unexpected error: %v", err)
+	}
+	if calls != failures+1 {
+		t.Fatalf("retry: called fn %d times, want %d", calls, failures+1)
+	}
+
+	calls = 0
+	err = retry(2, time.Millisecond, func() error {
+		calls++
+		return errors.New("always fails")
+	})
+	if err == nil {
+		t.Fatal("retry: expected error when every attempt fails, got nil")
+	}
+	if calls != 2 {
+		t.Fatalf("retry: called fn %d times, want 2", calls)
+	}
+}
+
+func TestMergeInheritTags(t *testing.T) {
+	table := Table{InheritTags: []string{"sysName", "missing"}}
+	scalars := map[string]models.Value{
+		"sysName": {Raw: "router1"},
+	}
+	rows := map[string]Row{
+		"a": {Values: map[string]models.Value{"ifDescr": {Raw: "eth0"}}},
+		"b": {Tags: map[string]models.Value{"ifIndex": {Raw: 1}}},
+	}
+
+	mergeInheritTags(table, scalars, rows)
+
+	for key, row := range rows {
+		tag, ok := row.Tags["sysName"]
+		if !ok {
+			t.Errorf("row %q: missing inherited tag sysName", key)
+			continue
+		}
+		if tag.Raw != "router1" {
+			t.Errorf("row %q: sysName = %v, want %q", key, tag.Raw, "router1")
+		}
+		if _, ok := row.Tags["missing"]; ok {
+			t.Errorf("row %q: tag %q should not be set when absent from scalars", key, "missing")
+		}
+	}
+
+	if rows["b"].Tags["ifIndex"].Raw != 1 {
+		t.Error("merging inherited tags clobbered a pre-existing tag")
+	}
+}
@@ -0,0 +1,195 @@
+package snmpquery
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/sleepinggenius2/gosmi/models"
+	"github.com/sleepinggenius2/gosmi/types"
+	"github.com/sleepinggenius2/gosnmp"
+)
+
+// RowEvent is emitted incrementally by TableStream as a table walk progresses.
+// Row holds the row as known so far (or in full, once Done is true); Column
+// names the column that was just filled in to produce this event, and is empty
+// when Row arrived whole, as it does when index addresses a single row. Err is
+// set if the query failed; Column then names the column whose walk failed
+// (empty for a single-row query) and the other fields are zero.
+type RowEvent struct {
+	Index  string
+	Row    Row
+	Column string
+	Done   bool
+	Err    error
+}
+
+// TableStream queries the client for the given table at the given index, emitting
+// a RowEvent on the returned channel as each column's value arrives rather than
+// building the complete map[string]Row in memory. The channel is closed once
+// every column's walk has completed or an error occurs.
+func (c Client) TableStream(table Table, index ...interface{}) (<-chan RowEvent, error) {
+	columns := table.Columns()
+	numColumns := len(columns)
+	if numColumns == 0 {
+		return nil, errors.New("No columns given")
+	}
+
+	indexLen := len(index)
+	events := make(chan RowEvent, 16)
+
+	if indexLen == len(table.Node.Index()) {
+		go c.singleRowStream(table.Node, columns, index, events)
+		return events, nil
+	}
+
+	indexSlice, err := table.Node.BuildIndex(index...)
+	if err != nil {
+		return nil, errors.Wrap(err, "Build index")
+	}
+
+	for _, column := range columns {
+		if !table.Node.ParentOf(column.Node.BaseNode) {
+			return nil, errors.Errorf("Column %s is not in table %s", column.Node.Name, table.Node.Name)
+		}
+	}
+
+	go c.tableStream(table, columns, indexLen, indexSlice, events)
+
+	return events, nil
+}
+
+func (c Client) singleRowStream(table models.TableNode, columns []Column, index []interface{}, events chan<- RowEvent) {
+	defer close(events)
+
+	results, err := c.singleRow(table, columns, index)
+	if err != nil {
+		events <- RowEvent{Err: err}
+		return
+	}
+
+	for key, row := range results {
+		events <- RowEvent{Index: key, Row: row, Done: true}
+	}
+}
+
+// errAborted is a sentinel returned from a walk callback to unwind BulkWalkOID
+// early once another column's walk has failed; it is never itself published as
+// a RowEvent
+var errAborted = errors.New("aborted")
+
+// mergeColumnValue records value for column.Name in the row identified by the
+// index OID indexParts, creating the row on first reference, and reports
+// whether every column of that row has now been filled. It returns an
+// independent copy of the row's Values so the caller can safely publish it on
+// the events channel without racing later writes to rows. Callers must hold
+// the lock guarding rows and filled.
+func mergeColumnValue(rows map[string]Row, filled map[string]int, table Table, indexLen int, indexParts types.Oid, numColumns int, columnName string, value models.Value) (rowCopy Row, done bool) {
+	key := GetIndexKey(indexParts)
+	row, ok := rows[key]
+	if !ok {
+		rowIndex, rowTags := getIndex(table.Node, indexLen, indexParts, table.IndexFormat)
+		row = Row{Index: rowIndex, Tags: rowTags, Values: make(map[string]models.Value, numColumns)}
+		rows[key] = row
+	}
+	row.Values[columnName] = value
+	filled[key]++
+
+	rowCopy = row
+	rowCopy.Values = make(map[string]models.Value, len(row.Values))
+	for name, v := range row.Values {
+		rowCopy.Values[name] = v
+	}
+	return rowCopy, filled[key] == numColumns
+}
+
+// tableStream runs one BulkWalkOID per column concurrently, each over its own
+// gosnmp connection (a single *gosnmp.GoSNMP cannot safely serve concurrent
+// request/response cycles), merging values by index key as they arrive and
+// publishing a RowEvent after each one. If any column's walk fails, the
+// remaining columns are aborted so the channel can still be closed promptly.
+func (c Client) tableStream(table Table, columns []Column, indexLen int, indexSlice types.Oid, events chan<- RowEvent) {
+	defer close(events)
+
+	numColumns := len(columns)
+
+	var mu sync.Mutex
+	rows := make(map[string]Row)
+	filled := make(map[string]int)
+
+	aborted := make(chan struct{})
+	var abortOnce sync.Once
+	abort := func() { abortOnce.Do(func() { close(aborted) }) }
+
+	var wg sync.WaitGroup
+	for _, column := range columns {
+		wg.Add(1)
+		go func(column Column) {
+			defer wg.Done()
+
+			snmp := *c.snmp
+			walker := Client{snmp: &snmp}
+			if err := walker.Connect(); err != nil {
+				select {
+				case events <- RowEvent{Column: column.Name, Err: err}:
+				case <-aborted:
+				}
+				abort()
+				return
+			}
+			defer walker.Close()
+
+			rootOid := column.Node.Oid
+			if len(indexSlice) != 0 {
+				rootOid = append(rootOid, indexSlice...)
+			}
+			oidLen := len(rootOid)
+
+			err := walker.snmp.BulkWalkOID(rootOid, func(pdu gosnmp.SnmpPDU) error {
+				select {
+				case <-aborted:
+					return errAborted
+				default:
+				}
+
+				switch pdu.Type {
+				case gosnmp.NoSuchObject:
+					return errors.New("No such object for " + column.Node.Name)
+				case gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+					return nil
+				}
+
+				indexParts := pdu.Oid[oidLen:]
+				key := GetIndexKey(indexParts)
+
+				var val interface{}
+				switch column.Node.Type.BaseType {
+				case types.BaseTypeOctetString, types.BaseTypeBits:
+					val = pdu.Value
+				default:
+					val, _ = models.ToInt64(pdu.Value)
+				}
+
+				mu.Lock()
+				rowCopy, rowDone := mergeColumnValue(rows, filled, table, indexLen, indexParts, numColumns, column.Name, column.FormatValue(val))
+				mu.Unlock()
+
+				select {
+				case events <- RowEvent{Index: key, Row: rowCopy, Column: column.Name, Done: rowDone}:
+					return nil
+				case <-aborted:
+					return errAborted
+				}
+			})
+			if err != nil && err != errAborted {
+				select {
+				case events <- RowEvent{Column: column.Name, Err: err}:
+				case <-aborted:
+				}
+				abort()
+			}
+		}(column)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,47 @@
+package snmpquery
+
+import (
+	"testing"
+
+	"github.com/sleepinggenius2/gosmi/models"
+	"github.com/sleepinggenius2/gosmi/types"
+)
+
+func TestMergeColumnValue(t *testing.T) {
+	var table Table
+	rows := make(map[string]Row)
+	filled := make(map[string]int)
+
+	key := []byte{0, 0, 0, 1}
+	indexParts := parseOidParts(key)
+
+	firstCopy, done := mergeColumnValue(rows, filled, table, 0, indexParts, 2, "colA", models.Value{Raw: "a"})
+	if done {
+		t.Fatal("row reported done after only one of two columns arrived")
+	}
+	if _, ok := firstCopy.Values["colA"]; !ok {
+		t.Fatal("returned row copy is missing the value just merged")
+	}
+
+	secondCopy, done := mergeColumnValue(rows, filled, table, 0, indexParts, 2, "colB", models.Value{Raw: "b"})
+	if !done {
+		t.Fatal("row not reported done after every column arrived")
+	}
+	if _, ok := secondCopy.Values["colA"]; !ok {
+		t.Fatal("row copy for the second column is missing the first column's value")
+	}
+
+	if _, ok := firstCopy.Values["colB"]; ok {
+		t.Fatal("mergeColumnValue mutated a previously published row copy")
+	}
+}
+
+// parseOidParts decodes b, a 4-byte big-endian key as GetIndexKey produces for
+// a single index part, back into that one-part types.Oid
+func parseOidParts(b []byte) types.Oid {
+	var part types.SmiSubId
+	for _, x := range b {
+		part = part<<8 | types.SmiSubId(x)
+	}
+	return types.Oid{part}
+}
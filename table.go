@@ -3,6 +3,7 @@ package snmpquery
 import (
 	"github.com/pkg/errors"
 
+	"github.com/sleepinggenius2/gosmi"
 	"github.com/sleepinggenius2/gosmi/models"
 	"github.com/sleepinggenius2/gosmi/types"
 	"github.com/sleepinggenius2/gosnmp"
@@ -22,6 +23,7 @@ func (c Column) FormatValue(value interface{}) models.Value {
 // Row represents a table row
 type Row struct {
 	Index  []models.Value
+	Tags   map[string]models.Value
 	Values map[string]models.Value
 }
 
@@ -29,7 +31,10 @@ type Table struct {
 	IndexFormat  models.Format
 	ColumnFormat models.Format
 	Node         models.TableNode
-	columns      []Column
+	// InheritTags names scalar query items that, when supplied to Pool.Table,
+	// are merged into the Tags of every row returned for this table
+	InheritTags []string
+	columns     []Column
 }
 
 func (t *Table) Column(node models.ColumnNode, format ...models.Format) {
@@ -57,6 +62,31 @@ func NewTable(node models.TableNode, indexFormat ...models.Format) Table {
 	return Table{Node: node, IndexFormat: models.ResolveFormat(indexFormat, models.FormatNone)}
 }
 
+// AutoPopulate registers every accessible column of the table's node as a Column,
+// unless columns have already been registered explicitly. Not-accessible columns
+// (e.g. INDEX objects also listed among the row's children) are skipped; their
+// values are reported on every Row via Row.Tags instead. models.ColumnNode itself
+// doesn't carry Access, so it's looked up from the MIB by OID.
+func (t *Table) AutoPopulate() {
+	if len(t.columns) > 0 {
+		return
+	}
+	for _, column := range t.Node.Columns() {
+		if node, err := gosmi.GetNodeByOID(column.Oid); err == nil && node.Access == types.AccessNotAccessible {
+			continue
+		}
+		t.Column(column)
+	}
+}
+
+// NewAutoTable creates a Table for node with all of its accessible columns already
+// registered via AutoPopulate, so callers don't have to hand-list every column
+func NewAutoTable(node models.TableNode, indexFormat ...models.Format) Table {
+	t := NewTable(node, indexFormat...)
+	t.AutoPopulate()
+	return t
+}
+
 // Table queries the client for the given table at the given index
 func (c Client) Table(table Table, index ...interface{}) (results map[string]Row, err error) {
 	columns := table.Columns()
@@ -112,13 +142,19 @@ func (c Client) singleRow(table models.TableNode, columns []Column, index []inte
 		return
 	}
 
+	indices := table.Index()
 	row := Row{
 		Index:  make([]models.Value, len(index)),
+		Tags:   make(map[string]models.Value, len(index)),
 		Values: result,
 	}
 
 	for i, indexValue := range index {
-		row.Index[i] = models.Value{Raw: indexValue}
+		val := models.Value{Raw: indexValue}
+		row.Index[i] = val
+		if i < len(indices) {
+			row.Tags[indices[i].Name] = val
+		}
 	}
 
 	return map[string]Row{GetIndexKey(indexSlice): row}, nil
@@ -138,9 +174,10 @@ func walkFunc(table Table, column Column, numColumns int, indexLen int, rootOid
 		indexParts := pdu.Oid[oidLen:]
 		index := GetIndexKey(indexParts)
 		if _, ok := results[index]; !ok {
-			rowIndex := getIndex(table.Node, indexLen, indexParts, table.IndexFormat)
+			rowIndex, rowTags := getIndex(table.Node, indexLen, indexParts, table.IndexFormat)
 			results[index] = Row{
 				Index:  rowIndex,
+				Tags:   rowTags,
 				Values: make(map[string]models.Value, numColumns),
 			}
 		}
@@ -167,10 +204,11 @@ func GetIndexKey(indexParts types.Oid) string {
 	return string(indexBytes)
 }
 
-func getIndex(table models.TableNode, indexLen int, indexParts types.Oid, indexFormat models.Format) (index []models.Value) {
+func getIndex(table models.TableNode, indexLen int, indexParts types.Oid, indexFormat models.Format) (index []models.Value, tags map[string]models.Value) {
 	indices := table.Index()
 	numIndices := len(indices)
 	index = make([]models.Value, numIndices-indexLen)
+	tags = make(map[string]models.Value, numIndices-indexLen)
 	implied := table.Implied()
 
 	for i := 0; i < numIndices-indexLen; i++ {
@@ -218,6 +256,7 @@ func getIndex(table models.TableNode, indexLen int, indexParts types.Oid, indexF
 			indexParts = indexParts[1:]
 		}
 		index[i] = indexNode.FormatValue(val, indexFormat)
+		tags[indexNode.Name] = index[i]
 	}
 
 	return
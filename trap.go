@@ -0,0 +1,211 @@
+package snmpquery
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+
+	"github.com/sleepinggenius2/gosmi"
+	"github.com/sleepinggenius2/gosmi/models"
+	"github.com/sleepinggenius2/gosmi/types"
+	"github.com/sleepinggenius2/gosnmp"
+)
+
+// snmpTrapOID is the well known OID of the varbind that carries the trap/inform identity in SNMPv2c and v3
+var snmpTrapOID = types.Oid{1, 3, 6, 1, 6, 3, 1, 1, 4, 1, 0}
+
+// snmpTraps is the root of the generic-trap OIDs used to build an snmpTrapOID
+// value out of an SNMPv1 Trap-PDU's generic-trap field, per the RFC 3584 conversion
+var snmpTraps = types.Oid{1, 3, 6, 1, 6, 3, 1, 1, 5}
+
+// TrapEvent is a single decoded trap or inform delivered to a TrapHandler
+type TrapEvent struct {
+	Name      string
+	Oid       types.Oid
+	Varbinds  map[string]models.Value
+	SrcIP     net.IP
+	Community string
+	// SecurityName, ContextName and ContextEngineID are populated for SNMPv3
+	// traps and informs; they are zero for SNMPv1/v2c
+	SecurityName    string
+	ContextName     string
+	ContextEngineID string
+}
+
+// TrapHandler receives trap events whose OID falls under the subtree it was registered for
+type TrapHandler func(event TrapEvent)
+
+type trapHandler struct {
+	oid     types.Oid
+	handler TrapHandler
+}
+
+// TrapListener is an snmpquery trap and inform receiver
+type TrapListener struct {
+	listener *gosnmp.TrapListener
+	handlers []trapHandler
+}
+
+// NewTrapListener creates a new TrapListener that accepts SNMPv1/v2c traps and informs sent with community
+func NewTrapListener(community string) *TrapListener {
+	t := &TrapListener{listener: gosnmp.NewTrapListener()}
+	t.listener.Params = &gosnmp.GoSNMP{
+		Version:   gosnmp.Version2c,
+		Community: community,
+	}
+	t.listener.OnNewTrap = t.dispatch
+	return t
+}
+
+// NewTrapListenerV3 creates a new TrapListener for SNMPv3 traps and informs, using the same USM parameters as NewV3
+func NewTrapListenerV3(username, authPassword, privPassword string) (*TrapListener, error) {
+	authProtocol, authPassphrase, err := getAuth(authPassword)
+	if err != nil {
+		return nil, err
+	}
+	privProtocol, privPassphrase, err := getPriv(privPassword)
+	if err != nil {
+		return nil, err
+	}
+	var msgFlags gosnmp.SnmpV3MsgFlags
+	if authProtocol == gosnmp.NoAuth {
+		if privProtocol == gosnmp.NoPriv {
+			msgFlags = gosnmp.NoAuthNoPriv
+		} else {
+			return nil, errors.Errorf("Privacy given with no authentication")
+		}
+	} else {
+		if privProtocol == gosnmp.NoPriv {
+			msgFlags = gosnmp.AuthNoPriv
+		} else {
+			msgFlags = gosnmp.AuthPriv
+		}
+	}
+
+	t := &TrapListener{listener: gosnmp.NewTrapListener()}
+	t.listener.Params = &gosnmp.GoSNMP{
+		Version:       gosnmp.Version3,
+		MsgFlags:      msgFlags,
+		SecurityModel: gosnmp.UserSecurityModel,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{
+			UserName:                 username,
+			AuthenticationProtocol:   authProtocol,
+			AuthenticationPassphrase: authPassphrase,
+			PrivacyProtocol:          privProtocol,
+			PrivacyPassphrase:        privPassphrase,
+		},
+	}
+	t.listener.OnNewTrap = t.dispatch
+	return t, nil
+}
+
+// Handle registers a handler for every trap or inform whose OID falls under node's OID
+func (t *TrapListener) Handle(node models.ScalarNode, handler TrapHandler) {
+	t.HandleOid(node.Oid, handler)
+}
+
+// HandleOid registers a handler for every trap or inform whose OID falls under oid
+func (t *TrapListener) HandleOid(oid types.Oid, handler TrapHandler) {
+	t.handlers = append(t.handlers, trapHandler{oid: oid, handler: handler})
+}
+
+// Listen opens addr (e.g. "0.0.0.0:162") and blocks, dispatching traps and informs to registered handlers
+// until Close is called
+func (t *TrapListener) Listen(addr string) error {
+	return t.listener.Listen(addr)
+}
+
+// Close stops the listener
+func (t *TrapListener) Close() {
+	t.listener.Close()
+}
+
+func (t *TrapListener) dispatch(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	if len(packet.Variables) == 0 && packet.PDUType != gosnmp.Trap {
+		return
+	}
+
+	var trapOid types.Oid
+	varbinds := make(map[string]models.Value, len(packet.Variables))
+
+	for _, variable := range packet.Variables {
+		name := variable.Oid.String()
+		var val models.Value
+		if node, err := gosmi.GetNodeByOID(variable.Oid); err == nil {
+			name = node.Name
+			val = node.FormatValue(variable.Value, models.FormatNone)
+		} else {
+			val = models.Value{Raw: variable.Value}
+		}
+		varbinds[name] = val
+
+		if oidEqual(variable.Oid, snmpTrapOID) {
+			if oid, ok := variable.Value.(types.Oid); ok {
+				trapOid = oid
+			}
+		}
+	}
+
+	if len(trapOid) == 0 && packet.PDUType == gosnmp.Trap {
+		// A genuine SNMPv1 Trap-PDU carries its identity via Enterprise/GenericTrap/
+		// SpecificTrap rather than a varbind; convert it per RFC 3584 section 3.6.
+		trapOid = v1TrapOid(packet)
+	}
+
+	if len(trapOid) == 0 {
+		return
+	}
+
+	name := trapOid.String()
+	if node, err := gosmi.GetNodeByOID(trapOid); err == nil {
+		name = node.Name
+	}
+
+	event := TrapEvent{
+		Name:            name,
+		Oid:             trapOid,
+		Varbinds:        varbinds,
+		SrcIP:           addr.IP,
+		Community:       packet.Community,
+		ContextName:     packet.ContextName,
+		ContextEngineID: packet.ContextEngineID,
+	}
+	if usm, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters); ok && usm != nil {
+		event.SecurityName = usm.UserName
+	}
+
+	for _, h := range t.handlers {
+		if oidHasPrefix(trapOid, h.oid) {
+			h.handler(event)
+		}
+	}
+}
+
+// v1TrapOid converts an SNMPv1 Trap-PDU's Enterprise/GenericTrap/SpecificTrap fields
+// into the snmpTrapOID value that SNMPv2c/v3 carry directly as a varbind, per the
+// conversion specified in RFC 3584 section 3.6
+func v1TrapOid(packet *gosnmp.SnmpPacket) types.Oid {
+	if packet.GenericTrap == 6 {
+		return append(append(types.Oid{}, packet.Enterprise...), 0, types.SmiSubId(packet.SpecificTrap))
+	}
+	return append(append(types.Oid{}, snmpTraps...), types.SmiSubId(packet.GenericTrap+1))
+}
+
+func oidEqual(a, b types.Oid) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func oidHasPrefix(oid, prefix types.Oid) bool {
+	if len(oid) < len(prefix) {
+		return false
+	}
+	return oidEqual(oid[:len(prefix)], prefix)
+}